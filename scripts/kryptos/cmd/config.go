@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"kryptos/internal/config"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var migrateWrite bool
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect and upgrade app config files",
+}
+
+var configMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade configs in --config-dir to the latest schema version",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		files, err := config.ListConfigs(configDir)
+		if err != nil {
+			return fmt.Errorf("listing configs from %s: %w", configDir, err)
+		}
+
+		for _, f := range files {
+			if err := migrateConfigFile(f); err != nil {
+				return fmt.Errorf("%s: %w", f, err)
+			}
+		}
+		return nil
+	},
+}
+
+func migrateConfigFile(path string) error {
+	original, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	migrated, versionPath, err := config.MigrateToLatest(original)
+	if err != nil {
+		return err
+	}
+
+	if len(versionPath) == 1 {
+		fmt.Printf("%s: already at %s\n", path, versionPath[0])
+		return nil
+	}
+
+	fmt.Printf("%s: %s\n", path, strings.Join(versionPath, " -> "))
+	fmt.Print(diffLines(string(original), string(migrated)))
+
+	if !migrateWrite {
+		return nil
+	}
+	if err := os.WriteFile(path, migrated, 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	fmt.Printf("%s: written\n", path)
+	return nil
+}
+
+// diffLines renders a line-level unified-style diff between before and
+// after, enough to review a migration before writing it back. Unchanged
+// lines are printed with a two-space prefix for context; it isn't meant to
+// replace a real diff tool for large files.
+func diffLines(before, after string) string {
+	beforeLines := strings.Split(strings.TrimRight(before, "\n"), "\n")
+	afterLines := strings.Split(strings.TrimRight(after, "\n"), "\n")
+
+	// Longest common subsequence of lines, filled back to front, then walked
+	// front to back to emit a minimal set of "-"/"+" hunks around unchanged
+	// context.
+	lcs := make([][]int, len(beforeLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(afterLines)+1)
+	}
+	for i := len(beforeLines) - 1; i >= 0; i-- {
+		for j := len(afterLines) - 1; j >= 0; j-- {
+			if beforeLines[i] == afterLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var b strings.Builder
+	i, j := 0, 0
+	for i < len(beforeLines) && j < len(afterLines) {
+		switch {
+		case beforeLines[i] == afterLines[j]:
+			b.WriteString("  " + beforeLines[i] + "\n")
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			b.WriteString("- " + beforeLines[i] + "\n")
+			i++
+		default:
+			b.WriteString("+ " + afterLines[j] + "\n")
+			j++
+		}
+	}
+	for ; i < len(beforeLines); i++ {
+		b.WriteString("- " + beforeLines[i] + "\n")
+	}
+	for ; j < len(afterLines); j++ {
+		b.WriteString("+ " + afterLines[j] + "\n")
+	}
+	return b.String()
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configMigrateCmd)
+
+	configMigrateCmd.Flags().BoolVar(&migrateWrite, "write", false, "write the migrated config back to disk (default: print the diff only)")
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import "testing"
+
+func TestDiffLines_UnchangedLinesAreContext(t *testing.T) {
+	before := "a\nb\nc\n"
+	after := "a\nb\nc\n"
+
+	got := diffLines(before, after)
+	want := "  a\n  b\n  c\n"
+	if got != want {
+		t.Errorf("diffLines(identical) = %q, want %q", got, want)
+	}
+}
+
+func TestDiffLines_OnlyFlagsChangedLines(t *testing.T) {
+	before := "apiVersion: legacy\nname: demo\nnamespace: demo-ns\n"
+	after := "apiVersion: kryptos.dev/v1\nname: demo\nnamespace: demo-ns\n"
+
+	got := diffLines(before, after)
+	want := "- apiVersion: legacy\n+ apiVersion: kryptos.dev/v1\n  name: demo\n  namespace: demo-ns\n"
+	if got != want {
+		t.Errorf("diffLines = %q, want %q", got, want)
+	}
+}
+
+func TestDiffLines_Insertions(t *testing.T) {
+	before := "a\nb\nc\n"
+	after := "a\nx\nb\nc\nd\n"
+
+	got := diffLines(before, after)
+	want := "  a\n+ x\n  b\n  c\n+ d\n"
+	if got != want {
+		t.Errorf("diffLines = %q, want %q", got, want)
+	}
+}
@@ -9,6 +9,18 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+var (
+	useBinary           bool
+	kubeconfig          string
+	certFile            string
+	controllerNamespace string
+	controllerName      string
+	configDir           string
+	headless            bool
+	headlessToStdout    bool
 )
 
 var rootCmd = &cobra.Command{
@@ -17,40 +29,38 @@ var rootCmd = &cobra.Command{
 	Long: `Kryptos is an enterprise-grade CLI tool for generating Kubernetes SealedSecrets.
 It provides a rich interactive interface for managing secrets across multiple applications.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		// Load Configs
-		configDir := "configs"
-		files, err := config.ListConfigs(configDir)
+		appConfigs, err := loadAppConfigs()
 		if err != nil {
-			fmt.Printf("Error listing configs from %s: %v\n", configDir, err)
+			fmt.Println(err)
 			os.Exit(1)
 		}
 
-		var appConfigs []*config.AppConfig
-		for _, f := range files {
-			cfg, err := config.LoadConfig(f)
-			if err != nil {
-				fmt.Printf("Warning: Could not load %s: %v\n", f, err)
-				continue
-			}
-			appConfigs = append(appConfigs, cfg)
-		}
-
-		if len(appConfigs) == 0 {
-			fmt.Println("No valid configurations found.")
-			os.Exit(1)
-		}
-
-		// Initialize Kubeseal
-		sealer, err := kubeseal.NewSealer()
+		// Initialize the sealer: in-process by default, kubeseal binary if
+		// --use-binary was passed.
+		sealer, err := newSealer()
 		if err != nil {
 			fmt.Printf("Error initializing kubeseal: %v\n", err)
 			os.Exit(1)
 		}
 
-		// Check connectivity (optional, warn on failure)
-		if err := sealer.CheckConnectivity(); err != nil {
-			fmt.Printf("Warning: Could not connect to sealed-secrets controller: %v\n", err)
-			fmt.Println("Proceeding anyway (offline sealing might fail if cert not cached)...")
+		// Check connectivity (optional, warn on failure). Skipped when
+		// --cert-file already supplied a cached cert for air-gapped use.
+		if certFile == "" {
+			if err := sealer.CheckConnectivity(); err != nil {
+				fmt.Printf("Warning: Could not connect to sealed-secrets controller: %v\n", err)
+				fmt.Println("Proceeding anyway (offline sealing might fail if cert not cached)...")
+			}
+		}
+
+		// Fall back to headless mode when stdin isn't a terminal (piped
+		// values, CI) or when explicitly requested.
+		if headless || !tui.IsInteractive() {
+			opts := tui.HeadlessOptions{Stdout: os.Stdout, ToStdout: headlessToStdout}
+			if err := tui.RunHeadless(cmd.Context(), os.Stdin, appConfigs, sealer, opts); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			return
 		}
 
 		// Start TUI
@@ -62,6 +72,70 @@ It provides a rich interactive interface for managing secrets across multiple ap
 	},
 }
 
+// loadAppConfigs loads every AppConfig found under configDir.
+func loadAppConfigs() ([]*config.AppConfig, error) {
+	files, err := config.ListConfigs(configDir)
+	if err != nil {
+		return nil, fmt.Errorf("listing configs from %s: %w", configDir, err)
+	}
+
+	var appConfigs []*config.AppConfig
+	for _, f := range files {
+		cfg, err := config.LoadConfig(f)
+		if err != nil {
+			fmt.Printf("Warning: Could not load %s: %v\n", f, err)
+			continue
+		}
+		appConfigs = append(appConfigs, cfg)
+	}
+
+	if len(appConfigs) == 0 {
+		return nil, fmt.Errorf("no valid configurations found in %s", configDir)
+	}
+	return appConfigs, nil
+}
+
+// loadAppConfig loads the single AppConfig whose AppName matches name.
+func loadAppConfig(name string) (*config.AppConfig, error) {
+	appConfigs, err := loadAppConfigs()
+	if err != nil {
+		return nil, err
+	}
+	for _, cfg := range appConfigs {
+		if cfg.AppName == name {
+			return cfg, nil
+		}
+	}
+	return nil, fmt.Errorf("no configuration found for app %q", name)
+}
+
+func newSealer() (*kubeseal.Sealer, error) {
+	if useBinary {
+		return kubeseal.NewBinarySealer()
+	}
+
+	// client-go's default loading rules read $KUBECONFIG, then ~/.kube/config,
+	// and the deferred loader falls back to in-cluster service-account config
+	// when those yield nothing; --kubeconfig, when set, takes precedence.
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeconfig
+	restConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("loading kubeconfig: %w", err)
+	}
+	sealer, err := kubeseal.NewSealer(restConfig, controllerNamespace, controllerName)
+	if err != nil {
+		return nil, err
+	}
+
+	if certFile != "" {
+		if err := sealer.LoadCertFromFile(certFile); err != nil {
+			return nil, fmt.Errorf("loading controller cert from %s: %w", certFile, err)
+		}
+	}
+	return sealer, nil
+}
+
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
@@ -70,5 +144,12 @@ func Execute() {
 }
 
 func init() {
-	// Global flags can be defined here
+	rootCmd.PersistentFlags().BoolVar(&useBinary, "use-binary", false, "shell out to the kubeseal binary instead of sealing in-process")
+	rootCmd.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", "", "path to kubeconfig (defaults to $KUBECONFIG, ~/.kube/config, then in-cluster config)")
+	rootCmd.PersistentFlags().StringVar(&certFile, "cert-file", "", "path to a cached sealed-secrets controller certificate (PEM), for air-gapped sealing without API access")
+	rootCmd.PersistentFlags().StringVar(&controllerNamespace, "controller-namespace", "kube-system", "namespace of the sealed-secrets controller")
+	rootCmd.PersistentFlags().StringVar(&controllerName, "controller-name", "sealed-secrets-controller", "service name of the sealed-secrets controller")
+	rootCmd.PersistentFlags().StringVar(&configDir, "config-dir", "configs", "directory containing app configs")
+	rootCmd.Flags().BoolVar(&headless, "headless", false, "read app/secret/field=value lines from stdin instead of launching the TUI")
+	rootCmd.Flags().BoolVar(&headlessToStdout, "stdout", false, "with --headless, write sealed YAML to stdout instead of the resolved secrets dir")
 }
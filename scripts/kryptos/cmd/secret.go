@@ -0,0 +1,542 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"kryptos/internal/certinfo"
+	"kryptos/internal/config"
+	"kryptos/internal/generator"
+	"kryptos/internal/kubeseal"
+	"kryptos/pkg/utils"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	ssv1alpha1 "github.com/bitnami-labs/sealed-secrets/pkg/apis/sealed-secrets/v1alpha1"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	sigsyaml "sigs.k8s.io/yaml"
+)
+
+var (
+	outputFormat    string
+	fromEnv         []string
+	generateFlags   []string
+	certFlag        bool
+	recoveryKeyPath string
+)
+
+var secretCmd = &cobra.Command{
+	Use:   "secret",
+	Short: "Manage SealedSecrets non-interactively",
+}
+
+var secretCreateCmd = &cobra.Command{
+	Use:   "create <app> <secret-name>",
+	Short: "Generate and seal a secret without the TUI",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appCfg, secretCfg, err := resolveSecret(args[0], args[1])
+		if err != nil {
+			return err
+		}
+
+		data, err := collectFieldData(*secretCfg, fromEnv, generateFlags)
+		if err != nil {
+			return err
+		}
+
+		outputPath, err := sealAndWrite(cmd, appCfg, *secretCfg, data)
+		if err != nil {
+			return err
+		}
+
+		return printResult(
+			map[string]string{"app": appCfg.AppName, "secret": secretCfg.Name, "file": outputPath},
+			fmt.Sprintf("Created %s\n", outputPath),
+		)
+	},
+}
+
+var secretLsCmd = &cobra.Command{
+	Use:   "ls [app]",
+	Short: "List generated SealedSecret files",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appConfigs, err := loadAppConfigs()
+		if err != nil {
+			return err
+		}
+		if len(args) == 1 {
+			cfg, err := loadAppConfig(args[0])
+			if err != nil {
+				return err
+			}
+			appConfigs = []*config.AppConfig{cfg}
+		}
+
+		var rows []secretListing
+		for _, appCfg := range appConfigs {
+			secretsDir, err := config.FindSecretsDir(appCfg.AppName)
+			if err != nil {
+				continue
+			}
+			matches, err := filepath.Glob(filepath.Join(secretsDir, "*.yaml"))
+			if err != nil {
+				return fmt.Errorf("listing %s: %w", secretsDir, err)
+			}
+			for _, m := range matches {
+				listing, err := readSecretListing(appCfg.AppName, m)
+				if err != nil {
+					fmt.Printf("Warning: skipping %s: %v\n", m, err)
+					continue
+				}
+				rows = append(rows, listing)
+			}
+		}
+
+		if outputFormat != "table" {
+			return printStructured(rows)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "APP\tFILE\tNAMESPACE\tFIELDS\tMODIFIED")
+		for _, r := range rows {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.App, r.File, r.Namespace, strings.Join(r.Fields, ","), r.Modified)
+		}
+		return w.Flush()
+	},
+}
+
+var secretInspectCmd = &cobra.Command{
+	Use:   "inspect <app>/<secret-name>",
+	Short: "Show a SealedSecret's metadata without revealing plaintext",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appName, secretName, err := splitAppSecret(args[0])
+		if err != nil {
+			return err
+		}
+
+		path, err := sealedSecretPath(appName, secretName)
+		if err != nil {
+			return err
+		}
+
+		if certFlag {
+			return inspectCert(path)
+		}
+
+		sealed, err := readSealedSecret(path)
+		if err != nil {
+			return err
+		}
+
+		info := sealedSecretInfo{
+			Name:          sealed.Name,
+			Namespace:     sealed.Namespace,
+			Controller:    fmt.Sprintf("%s/%s", controllerNamespace, controllerName),
+			Type:          string(sealed.Spec.Template.Type),
+			EncryptedKeys: encryptedFieldNames(sealed),
+		}
+
+		if outputFormat != "table" {
+			return printStructured(info)
+		}
+
+		fmt.Printf("Name:           %s\n", info.Name)
+		fmt.Printf("Namespace:      %s\n", info.Namespace)
+		fmt.Printf("Controller:     %s\n", info.Controller)
+		fmt.Printf("Type:           %s\n", info.Type)
+		fmt.Printf("Encrypted keys: %d (%s)\n", len(info.EncryptedKeys), strings.Join(info.EncryptedKeys, ", "))
+		return nil
+	},
+}
+
+var secretRmCmd = &cobra.Command{
+	Use:   "rm <app>/<secret-name>",
+	Short: "Delete a generated SealedSecret file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appName, secretName, err := splitAppSecret(args[0])
+		if err != nil {
+			return err
+		}
+
+		path, err := sealedSecretPath(appName, secretName)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("removing %s: %w", path, err)
+		}
+
+		return printResult(map[string]string{"file": path}, fmt.Sprintf("Removed %s\n", path))
+	},
+}
+
+var secretRotateCmd = &cobra.Command{
+	Use:   "rotate <app>/<secret-name>",
+	Short: "Regenerate generator-backed fields of a SealedSecret",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appName, secretName, err := splitAppSecret(args[0])
+		if err != nil {
+			return err
+		}
+
+		appCfg, secretCfg, err := resolveSecret(appName, secretName)
+		if err != nil {
+			return err
+		}
+
+		// Only fields with a Generator are regenerated; fields with a Default
+		// get it again, and static StringData is re-applied unchanged by
+		// GenerateRawSecret.
+		data := make(map[string]string)
+		var unrecoverable []string
+		for _, field := range secretCfg.Fields {
+			if field.Generator != "" {
+				val, err := generator.ResolveFieldValue(field)
+				if err != nil {
+					return err
+				}
+				data[field.Name] = val
+				continue
+			}
+			if field.Default != "" {
+				data[field.Name] = field.Default
+				continue
+			}
+
+			// A required field with no generator and no static fallback was
+			// hand-typed at create time; SealedSecrets are one-way encrypted,
+			// so rotate has no way to recover its value.
+			if field.Required {
+				if _, ok := secretCfg.StringData[field.Name]; !ok && field.Default == "" {
+					unrecoverable = append(unrecoverable, field.Name)
+				}
+			}
+		}
+		if len(unrecoverable) > 0 {
+			return fmt.Errorf("cannot rotate %s: required field(s) %s were hand-entered and can't be recovered from the sealed secret; re-run `kryptos secret create` to supply them again", args[0], strings.Join(unrecoverable, ", "))
+		}
+
+		outputPath, err := sealAndWrite(cmd, appCfg, *secretCfg, data)
+		if err != nil {
+			return err
+		}
+
+		return printResult(map[string]string{"file": outputPath}, fmt.Sprintf("Rotated %s\n", outputPath))
+	},
+}
+
+type secretListing struct {
+	App       string   `json:"app"`
+	File      string   `json:"file"`
+	Namespace string   `json:"namespace"`
+	Fields    []string `json:"fields"`
+	Modified  string   `json:"modified"`
+}
+
+type sealedSecretInfo struct {
+	Name          string   `json:"name"`
+	Namespace     string   `json:"namespace"`
+	Controller    string   `json:"controller"`
+	Type          string   `json:"type"`
+	EncryptedKeys []string `json:"encryptedKeys"`
+}
+
+func resolveSecret(appName, secretName string) (*config.AppConfig, *config.Secret, error) {
+	appCfg, err := loadAppConfig(appName)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range appCfg.Secrets {
+		if appCfg.Secrets[i].Name == secretName {
+			return appCfg, &appCfg.Secrets[i], nil
+		}
+	}
+	return nil, nil, fmt.Errorf("no secret %q configured for app %q", secretName, appName)
+}
+
+// collectFieldData merges config-declared generators/defaults with
+// --from-env and --generate overrides, in that precedence order.
+func collectFieldData(secretCfg config.Secret, fromEnv, generate []string) (map[string]string, error) {
+	data := make(map[string]string)
+
+	for _, field := range secretCfg.Fields {
+		val, err := generator.ResolveFieldValue(field)
+		if err != nil {
+			return nil, err
+		}
+		if val != "" {
+			data[field.Name] = val
+		}
+	}
+
+	for _, kv := range fromEnv {
+		key, val, ok := strings.Cut(kv, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --from-env value %q, expected KEY=VALUE", kv)
+		}
+		data[key] = val
+	}
+
+	for _, g := range generate {
+		field, keyword, ok := strings.Cut(g, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --generate value %q, expected field=keyword", g)
+		}
+		val, matched, err := utils.GenerateByKeyword(keyword)
+		if err != nil {
+			return nil, err
+		}
+		if !matched {
+			return nil, fmt.Errorf("unknown generator keyword %q for field %q", keyword, field)
+		}
+		data[field] = val
+	}
+
+	return data, nil
+}
+
+func sealAndWrite(cmd *cobra.Command, appCfg *config.AppConfig, secretCfg config.Secret, data map[string]string) (string, error) {
+	rawSecret, err := generator.GenerateRawSecret(appCfg, secretCfg, data)
+	if err != nil {
+		return "", err
+	}
+
+	sealer, err := newSealer()
+	if err != nil {
+		return "", err
+	}
+
+	sealedSecret, err := sealer.SealSecret(cmd.Context(), rawSecret, ssv1alpha1.StrictScope)
+	if err != nil {
+		return "", err
+	}
+
+	sealedYAML, err := kubeseal.MarshalSealedSecret(sealedSecret)
+	if err != nil {
+		return "", err
+	}
+
+	path, err := sealedSecretPath(appCfg.AppName, secretCfg.Name)
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, sealedYAML, 0644); err != nil {
+		return "", fmt.Errorf("writing %s: %w", path, err)
+	}
+
+	if rawSecret.Type == corev1.SecretTypeTLS {
+		if err := cacheTLSCertInfo(appCfg.AppName, secretCfg.Name, rawSecret.Data[corev1.TLSCertKey]); err != nil {
+			fmt.Printf("Warning: could not cache certificate info for %s: %v\n", secretCfg.Name, err)
+		}
+	}
+
+	return path, nil
+}
+
+// cacheTLSCertInfo stores a TLS secret's certificate metadata (no key
+// material) alongside its SealedSecret, so `inspect --cert` and the TUI's
+// expiry warning don't need to decrypt anything.
+func cacheTLSCertInfo(appName, secretName string, certPEM []byte) error {
+	info, err := certinfo.Parse(certPEM)
+	if err != nil {
+		return err
+	}
+	secretsDir, err := config.FindSecretsDir(appName)
+	if err != nil {
+		return err
+	}
+	return certinfo.WriteCache(secretsDir, secretName, info)
+}
+
+// inspectCert prints certificate details (Issuer, Subject, SANs, validity,
+// days-until-expiry) for a tls.crt field, either decrypted locally from a
+// SealedSecret (with --recovery-private-key) or read from an already
+// unsealed file.
+func inspectCert(path string) error {
+	pemData, err := extractCertPEM(path)
+	if err != nil {
+		return err
+	}
+
+	info, err := certinfo.Parse(pemData)
+	if err != nil {
+		return err
+	}
+
+	if outputFormat != "table" {
+		return printStructured(struct {
+			certinfo.Info
+			DaysUntilExpiry int `json:"daysUntilExpiry"`
+		}{Info: *info, DaysUntilExpiry: info.DaysUntilExpiry()})
+	}
+
+	fmt.Printf("Issuer:            %s\n", info.Issuer)
+	fmt.Printf("Subject:           %s\n", info.Subject)
+	fmt.Printf("SANs:              %s\n", strings.Join(info.SANs, ", "))
+	fmt.Printf("Not Before:        %s\n", info.NotBefore.Format(time.RFC3339))
+	fmt.Printf("Not After:         %s\n", info.NotAfter.Format(time.RFC3339))
+	fmt.Printf("Days Until Expiry: %d\n", info.DaysUntilExpiry())
+	return nil
+}
+
+func extractCertPEM(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	// Already-unsealed plain Secret file.
+	var plain corev1.Secret
+	if err := sigsyaml.Unmarshal(data, &plain); err == nil && plain.Kind == "Secret" {
+		if pemData, ok := findCertField(plain.Data, plain.StringData); ok {
+			return pemData, nil
+		}
+	}
+
+	sealed, err := kubeseal.UnmarshalSealedSecret(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if recoveryKeyPath == "" {
+		return nil, fmt.Errorf("%s is a SealedSecret; pass --recovery-private-key to decrypt it locally", path)
+	}
+
+	sealer := kubeseal.NewOfflineSealer()
+	if err := sealer.LoadPrivateKeyFromFile(recoveryKeyPath); err != nil {
+		return nil, err
+	}
+
+	plainSecret, err := sealer.Unseal(sealed)
+	if err != nil {
+		return nil, err
+	}
+
+	pemData, ok := findCertField(plainSecret.Data, nil)
+	if !ok {
+		return nil, fmt.Errorf("no PEM-looking field found in %s", path)
+	}
+	return pemData, nil
+}
+
+func findCertField(data map[string][]byte, stringData map[string]string) ([]byte, bool) {
+	if v, ok := data[corev1.TLSCertKey]; ok {
+		return v, true
+	}
+	if v, ok := stringData[corev1.TLSCertKey]; ok {
+		return []byte(v), true
+	}
+	for _, v := range data {
+		if bytes.Contains(v, []byte("-----BEGIN")) {
+			return v, true
+		}
+	}
+	for _, v := range stringData {
+		if strings.Contains(v, "-----BEGIN") {
+			return []byte(v), true
+		}
+	}
+	return nil, false
+}
+
+func sealedSecretPath(appName, secretName string) (string, error) {
+	secretsDir, err := config.FindSecretsDir(appName)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(secretsDir, secretName+".yaml"), nil
+}
+
+func readSealedSecret(path string) (*ssv1alpha1.SealedSecret, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return kubeseal.UnmarshalSealedSecret(data)
+}
+
+func encryptedFieldNames(sealed *ssv1alpha1.SealedSecret) []string {
+	fields := make([]string, 0, len(sealed.Spec.EncryptedData))
+	for k := range sealed.Spec.EncryptedData {
+		fields = append(fields, k)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func readSecretListing(appName, path string) (secretListing, error) {
+	sealed, err := readSealedSecret(path)
+	if err != nil {
+		return secretListing{}, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return secretListing{}, err
+	}
+	return secretListing{
+		App:       appName,
+		File:      path,
+		Namespace: sealed.Namespace,
+		Fields:    encryptedFieldNames(sealed),
+		Modified:  info.ModTime().Format("2006-01-02T15:04:05Z07:00"),
+	}, nil
+}
+
+func splitAppSecret(ref string) (app, secret string, err error) {
+	app, secret, ok := strings.Cut(ref, "/")
+	if !ok || app == "" || secret == "" {
+		return "", "", fmt.Errorf("expected <app>/<secret-name>, got %q", ref)
+	}
+	return app, secret, nil
+}
+
+// printResult prints a human message by default, or the structured result
+// when --output requests json/yaml (for scripting).
+func printResult(v interface{}, humanMsg string) error {
+	if outputFormat != "table" {
+		return printStructured(v)
+	}
+	fmt.Print(humanMsg)
+	return nil
+}
+
+func printStructured(v interface{}) error {
+	switch outputFormat {
+	case "json":
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	default:
+		data, err := sigsyaml.Marshal(v)
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(data))
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(secretCmd)
+	secretCmd.AddCommand(secretCreateCmd, secretLsCmd, secretInspectCmd, secretRmCmd, secretRotateCmd)
+
+	secretCmd.PersistentFlags().StringVar(&outputFormat, "output", "table", "output format: table|json|yaml")
+
+	secretCreateCmd.Flags().StringArrayVar(&fromEnv, "from-env", nil, "KEY=VALUE pairs to populate fields (repeatable)")
+	secretCreateCmd.Flags().StringArrayVar(&generateFlags, "generate", nil, "field=keyword generator overrides, e.g. password=secure (repeatable)")
+
+	secretInspectCmd.Flags().BoolVar(&certFlag, "cert", false, "parse and show tls.crt expiry info instead of envelope metadata")
+	secretInspectCmd.Flags().StringVar(&recoveryKeyPath, "recovery-private-key", "", "controller RSA private key, for local decryption with --cert")
+}
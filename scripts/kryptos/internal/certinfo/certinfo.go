@@ -0,0 +1,83 @@
+// Package certinfo parses X.509 certificates out of PEM data and caches
+// their (non-secret) metadata so expiry can be checked without decrypting
+// a SealedSecret.
+package certinfo
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Info holds the human-relevant fields of a certificate. It never contains
+// key material, so it's safe to cache on disk unencrypted.
+type Info struct {
+	Issuer    string    `json:"issuer"`
+	Subject   string    `json:"subject"`
+	SANs      []string  `json:"sans"`
+	NotBefore time.Time `json:"notBefore"`
+	NotAfter  time.Time `json:"notAfter"`
+}
+
+// DaysUntilExpiry returns the (possibly negative) number of days until
+// NotAfter, computed at call time rather than cached.
+func (i Info) DaysUntilExpiry() int {
+	return int(time.Until(i.NotAfter).Hours() / 24)
+}
+
+// Parse extracts the first PEM-encoded certificate found in data.
+func Parse(data []byte) (*Info, error) {
+	for {
+		block, rest := pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type == "CERTIFICATE" {
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("parsing certificate: %w", err)
+			}
+			return &Info{
+				Issuer:    cert.Issuer.String(),
+				Subject:   cert.Subject.String(),
+				SANs:      cert.DNSNames,
+				NotBefore: cert.NotBefore,
+				NotAfter:  cert.NotAfter,
+			}, nil
+		}
+		data = rest
+	}
+	return nil, fmt.Errorf("no PEM certificate found")
+}
+
+// cacheFileName is the sidecar filename used to cache a TLS secret's
+// certificate metadata next to its SealedSecret file.
+func cacheFileName(secretName string) string {
+	return secretName + ".tls-cert.json"
+}
+
+// WriteCache persists a certificate's metadata alongside its SealedSecret.
+func WriteCache(dir, secretName string, info *Info) error {
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding cert cache: %w", err)
+	}
+	return os.WriteFile(filepath.Join(dir, cacheFileName(secretName)), data, 0644)
+}
+
+// ReadCache loads a previously cached certificate's metadata, if any.
+func ReadCache(dir, secretName string) (*Info, error) {
+	data, err := os.ReadFile(filepath.Join(dir, cacheFileName(secretName)))
+	if err != nil {
+		return nil, err
+	}
+	var info Info
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("decoding cert cache: %w", err)
+	}
+	return &info, nil
+}
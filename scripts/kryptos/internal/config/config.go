@@ -27,6 +27,16 @@ type Secret struct {
 	Fields      []SecretField // Unified list of fields
 	Labels      map[string]string
 	StringData  map[string]string
+	TLS         *TLSConfig // Only used when Type is "kubernetes.io/tls"
+}
+
+// TLSConfig declares how a self-signed certificate should be generated for
+// a Secret of type "kubernetes.io/tls" when no tls.crt/tls.key paths are
+// supplied by the user.
+type TLSConfig struct {
+	CommonName   string
+	SANs         []string
+	DurationDays int
 }
 
 type SecretField struct {
@@ -65,6 +75,7 @@ type kv1Secret struct {
 	Fields      []kv1Field        `yaml:"fields"`
 	StringData  map[string]string `yaml:"stringData"`
 	Labels      map[string]string `yaml:"labels"`
+	TLS         *kv1TLSSpec       `yaml:"tls,omitempty"`
 }
 
 type kv1Field struct {
@@ -76,42 +87,27 @@ type kv1Field struct {
 	Length    int    `yaml:"length"`
 }
 
-// Legacy Schema Definitions
-
-type legacyAppConfig struct {
-	AppName     string         `yaml:"app_name"`
-	DisplayName string         `yaml:"display_name"`
-	Namespace   string         `yaml:"namespace"`
-	Secrets     []legacySecret `yaml:"secrets"`
+type kv1TLSSpec struct {
+	CommonName   string   `yaml:"commonName"`
+	SANs         []string `yaml:"sans"`
+	DurationDays int      `yaml:"durationDays"`
 }
 
-type legacySecret struct {
-	Name        string            `yaml:"name"`
-	DisplayName string            `yaml:"display_name"`
-	Type        string            `yaml:"type"`
-	Description string            `yaml:"description"`
-	Keys        []string          `yaml:"keys"`
-	StringData  map[string]string `yaml:"stringData"`
-	Labels      map[string]string `yaml:"labels"`
-}
-
-// LoadConfig reads a YAML configuration file and returns a unified AppConfig
+// LoadConfig reads a YAML configuration file, upgrading it through the
+// registered schema migration chain (see migration.go) if it isn't already
+// at the latest version, and returns a unified AppConfig.
 func LoadConfig(path string) (*AppConfig, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("error reading config file: %w", err)
 	}
 
-	// 1. Try mapping the API Version
-	var header struct {
-		APIVersion string `yaml:"apiVersion"`
-	}
-	if err := yaml.Unmarshal(data, &header); err == nil && header.APIVersion == "kryptos.dev/v1" {
-		return loadV1Config(data)
+	migrated, _, err := MigrateToLatest(data)
+	if err != nil {
+		return nil, fmt.Errorf("error migrating config %s: %w", path, err)
 	}
 
-	// 2. Fallback to Legacy
-	return loadLegacyConfig(data)
+	return loadV1Config(migrated)
 }
 
 func loadV1Config(data []byte) (*AppConfig, error) {
@@ -135,6 +131,13 @@ func loadV1Config(data []byte) (*AppConfig, error) {
 			Labels:      s.Labels,
 			StringData:  s.StringData,
 		}
+		if s.TLS != nil {
+			secret.TLS = &TLSConfig{
+				CommonName:   s.TLS.CommonName,
+				SANs:         s.TLS.SANs,
+				DurationDays: s.TLS.DurationDays,
+			}
+		}
 		for _, f := range s.Fields {
 			secret.Fields = append(secret.Fields, SecretField{
 				Name:      f.Name,
@@ -150,39 +153,6 @@ func loadV1Config(data []byte) (*AppConfig, error) {
 	return app, nil
 }
 
-func loadLegacyConfig(data []byte) (*AppConfig, error) {
-	var legacy legacyAppConfig
-	if err := yaml.Unmarshal(data, &legacy); err != nil {
-		return nil, fmt.Errorf("error parsing legacy config: %w", err)
-	}
-
-	app := &AppConfig{
-		AppName:     legacy.AppName,
-		DisplayName: legacy.DisplayName,
-		Namespace:   legacy.Namespace,
-	}
-
-	for _, s := range legacy.Secrets {
-		secret := Secret{
-			Name:        s.Name,
-			DisplayName: s.DisplayName,
-			Type:        s.Type,
-			Description: s.Description,
-			Labels:      s.Labels,
-			StringData:  s.StringData,
-		}
-		// Convert string keys to Fields
-		for _, k := range s.Keys {
-			secret.Fields = append(secret.Fields, SecretField{
-				Name:   k,
-				Prompt: k, // Default prompt is the key name
-			})
-		}
-		app.Secrets = append(app.Secrets, secret)
-	}
-	return app, nil
-}
-
 // ListConfigs finds all YAML configs in the given directory
 func ListConfigs(dir string) ([]string, error) {
 	files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
@@ -0,0 +1,174 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/token"
+)
+
+// legacyToV1Migration upgrades the hand-rolled "app_name/display_name/keys"
+// shape into kryptos.dev/v1 (displayName-style field names, typed Fields
+// instead of a bare key list).
+type legacyToV1Migration struct{}
+
+func (legacyToV1Migration) From() string { return "legacy" }
+func (legacyToV1Migration) To() string   { return "kryptos.dev/v1" }
+
+// Migrate rebuilds the document as a kryptos.dev/v1 mapping by mutating the
+// AST rather than templating text: every renamed key swaps in a new,
+// known-safe literal StringNode, but each value node from the source
+// document is reused verbatim, so user data keeps its original
+// quoting/escaping and any comment attached to that node rides along into
+// the new tree.
+func (legacyToV1Migration) Migrate(file *ast.File) (*ast.File, error) {
+	if len(file.Docs) == 0 {
+		return nil, fmt.Errorf("empty config document")
+	}
+	root, ok := file.Docs[0].Body.(*ast.MappingNode)
+	if !ok {
+		return nil, fmt.Errorf("expected a mapping at the document root")
+	}
+
+	nameMV, ok := findValue(root, "app_name")
+	if !ok {
+		return nil, fmt.Errorf("missing required field %q", "app_name")
+	}
+	renameKey(nameMV, "name")
+	metadataEntries := []*ast.MappingValueNode{nameMV}
+
+	if displayMV, ok := findValue(root, "display_name"); ok {
+		renameKey(displayMV, "displayName")
+		metadataEntries = append(metadataEntries, displayMV)
+	}
+	if nsMV, ok := findValue(root, "namespace"); ok {
+		metadataEntries = append(metadataEntries, nsMV)
+	}
+
+	secretsMV, ok := findValue(root, "secrets")
+	if !ok {
+		return nil, fmt.Errorf("legacy config missing top-level 'secrets'")
+	}
+	secretsSeq, ok := secretsMV.Value.(*ast.SequenceNode)
+	if !ok {
+		return nil, fmt.Errorf("expected 'secrets' to be a sequence")
+	}
+
+	migratedSecrets := newSequence()
+	for _, item := range secretsSeq.Values {
+		secretMap, ok := item.(*ast.MappingNode)
+		if !ok {
+			return nil, fmt.Errorf("expected each secret to be a mapping")
+		}
+
+		migratedSecret, err := migrateLegacySecret(secretMap)
+		if err != nil {
+			return nil, err
+		}
+		migratedSecrets.Values = append(migratedSecrets.Values, migratedSecret)
+	}
+
+	metadata := newMapping(metadataEntries...)
+
+	secretsMV.Value = migratedSecrets
+	spec := newMapping(secretsMV)
+
+	out := newMapping(
+		newKV("apiVersion", newScalar("kryptos.dev/v1")),
+		newKV("kind", newScalar("KryptosConfig")),
+		newKV("metadata", metadata),
+		newKV("spec", spec),
+	)
+	if c := root.GetComment(); c != nil {
+		out.SetComment(c)
+	}
+
+	file.Docs[0].Body = out
+	return file, nil
+}
+
+func migrateLegacySecret(secretMap *ast.MappingNode) (*ast.MappingNode, error) {
+	nameMV, ok := findValue(secretMap, "name")
+	if !ok {
+		return nil, fmt.Errorf("secret missing required field %q", "name")
+	}
+	entries := []*ast.MappingValueNode{nameMV}
+
+	if displayMV, ok := findValue(secretMap, "display_name"); ok {
+		renameKey(displayMV, "displayName")
+		entries = append(entries, displayMV)
+	}
+	if typeMV, ok := findValue(secretMap, "type"); ok {
+		entries = append(entries, typeMV)
+	}
+	if descMV, ok := findValue(secretMap, "description"); ok {
+		entries = append(entries, descMV)
+	}
+
+	if keysMV, ok := findValue(secretMap, "keys"); ok {
+		keysSeq, ok := keysMV.Value.(*ast.SequenceNode)
+		if !ok {
+			return nil, fmt.Errorf("expected 'keys' to be a sequence")
+		}
+
+		fields := newSequence()
+		for _, k := range keysSeq.Values {
+			fields.Values = append(fields.Values, newMapping(newKV("name", k)))
+		}
+
+		fieldsMV := newKV("fields", fields)
+		if c := keysMV.GetComment(); c != nil {
+			fieldsMV.SetComment(c)
+		}
+		entries = append(entries, fieldsMV)
+	}
+
+	if sdMV, ok := findValue(secretMap, "stringData"); ok {
+		entries = append(entries, sdMV)
+	}
+	if labelsMV, ok := findValue(secretMap, "labels"); ok {
+		entries = append(entries, labelsMV)
+	}
+
+	migrated := newMapping(entries...)
+	if c := secretMap.GetComment(); c != nil {
+		migrated.SetComment(c)
+	}
+	return migrated, nil
+}
+
+func findValue(m *ast.MappingNode, key string) (*ast.MappingValueNode, bool) {
+	for _, v := range m.Values {
+		if keyString(v.Key) == key {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+func keyString(n ast.Node) string {
+	return strings.Trim(n.String(), `"'`)
+}
+
+// renameKey swaps mv's key for newName, a fixed literal we control, while
+// leaving mv's value and any attached comment untouched.
+func renameKey(mv *ast.MappingValueNode, newName string) {
+	mv.Key = newScalar(newName)
+}
+
+func newScalar(s string) *ast.StringNode {
+	return ast.String(token.New(s, s, &token.Position{}))
+}
+
+func newKV(key string, value ast.Node) *ast.MappingValueNode {
+	return ast.MappingValue(token.New(key, key, &token.Position{}), newScalar(key), value)
+}
+
+func newMapping(values ...*ast.MappingValueNode) *ast.MappingNode {
+	return ast.Mapping(token.New("", "", &token.Position{}), false, values...)
+}
+
+func newSequence() *ast.SequenceNode {
+	return ast.Sequence(token.New("", "", &token.Position{}), false)
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+const legacyConfigYAML = `app_name: demo
+display_name: Demo App
+namespace: demo-ns
+secrets:
+  # per-secret comment
+  - name: api-creds
+    display_name: API Credentials
+    type: Opaque
+    keys:
+      - token # inline comment
+      - secret
+`
+
+func TestMigrateToLatest_LegacyToV1(t *testing.T) {
+	migrated, path, err := MigrateToLatest([]byte(legacyConfigYAML))
+	if err != nil {
+		t.Fatalf("MigrateToLatest: %v", err)
+	}
+
+	if want := []string{"legacy", "kryptos.dev/v1"}; strings.Join(path, "->") != strings.Join(want, "->") {
+		t.Fatalf("version path = %v, want %v", path, want)
+	}
+
+	out := string(migrated)
+	for _, want := range []string{
+		"apiVersion: kryptos.dev/v1",
+		"kind: KryptosConfig",
+		"name: demo",
+		"displayName: Demo App",
+		"namespace: demo-ns",
+		"displayName: API Credentials",
+		"fields:",
+		"name: token",
+		"name: secret",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("migrated output missing %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestMigrateToLatest_PreservesComments(t *testing.T) {
+	migrated, _, err := MigrateToLatest([]byte(legacyConfigYAML))
+	if err != nil {
+		t.Fatalf("MigrateToLatest: %v", err)
+	}
+
+	out := string(migrated)
+	if !strings.Contains(out, "per-secret comment") {
+		t.Errorf("migrated output dropped the secrets-list comment, got:\n%s", out)
+	}
+	if !strings.Contains(out, "inline comment") {
+		t.Errorf("migrated output dropped the inline key comment, got:\n%s", out)
+	}
+}
+
+func TestMigrateToLatest_PreservesHashInValues(t *testing.T) {
+	const legacyWithHash = `app_name: demo
+secrets:
+  - name: api-creds
+    display_name: "weird#name"
+    keys:
+      - token
+`
+	migrated, _, err := MigrateToLatest([]byte(legacyWithHash))
+	if err != nil {
+		t.Fatalf("MigrateToLatest: %v", err)
+	}
+
+	if !strings.Contains(string(migrated), "weird#name") {
+		t.Errorf("migration truncated a value containing '#', got:\n%s", migrated)
+	}
+}
+
+func TestMigrateToLatest_AlreadyLatest(t *testing.T) {
+	const v1 = `apiVersion: kryptos.dev/v1
+kind: KryptosConfig
+metadata:
+  name: demo
+spec:
+  secrets: []
+`
+	migrated, path, err := MigrateToLatest([]byte(v1))
+	if err != nil {
+		t.Fatalf("MigrateToLatest: %v", err)
+	}
+	if len(path) != 1 || path[0] != "kryptos.dev/v1" {
+		t.Fatalf("version path = %v, want [kryptos.dev/v1]", path)
+	}
+	if string(migrated) != v1 {
+		t.Errorf("already-latest config was rewritten:\n%s", migrated)
+	}
+}
+
+func TestMigrateToLatest_MissingAppName(t *testing.T) {
+	const broken = `secrets: []
+`
+	if _, _, err := MigrateToLatest([]byte(broken)); err == nil {
+		t.Fatal("expected an error for a legacy config missing app_name")
+	}
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+)
+
+// SchemaMigration upgrades a config document from one schema version to the
+// next. Migrations operate on the parsed YAML AST (rather than unmarshaling
+// into a Go struct and re-marshaling) so that comments and field order in
+// the user's file survive being written back.
+type SchemaMigration interface {
+	From() string
+	To() string
+	Migrate(file *ast.File) (*ast.File, error)
+}
+
+// migrations is the registered chain, tried in registration order. New
+// schema versions are added by registering one more migration here; LoadConfig
+// and `kryptos config migrate` don't need to change.
+var migrations []SchemaMigration
+
+// RegisterMigration adds m to the chain used by LoadConfig and
+// `kryptos config migrate`.
+func RegisterMigration(m SchemaMigration) {
+	migrations = append(migrations, m)
+}
+
+func init() {
+	RegisterMigration(legacyToV1Migration{})
+}
+
+func migrationFrom(version string) SchemaMigration {
+	for _, m := range migrations {
+		if m.From() == version {
+			return m
+		}
+	}
+	return nil
+}
+
+// DetectVersion returns the schema version declared by data's apiVersion
+// field, or "legacy" if it doesn't have one.
+func DetectVersion(data []byte) string {
+	var header struct {
+		APIVersion string `yaml:"apiVersion"`
+	}
+	if err := yaml.Unmarshal(data, &header); err == nil && header.APIVersion != "" {
+		return header.APIVersion
+	}
+	return "legacy"
+}
+
+// MigrateToLatest walks the registered migration chain starting at data's
+// detected version, returning the upgraded YAML and the version path it
+// took (e.g. ["legacy", "kryptos.dev/v1"]). If data's version has no
+// registered migration (it's already at the latest known schema), it's
+// returned unchanged.
+func MigrateToLatest(data []byte) ([]byte, []string, error) {
+	version := DetectVersion(data)
+	path := []string{version}
+
+	for {
+		m := migrationFrom(version)
+		if m == nil {
+			return data, path, nil
+		}
+
+		file, err := parser.ParseBytes(data, parser.ParseComments)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parsing %s config: %w", version, err)
+		}
+
+		migrated, err := m.Migrate(file)
+		if err != nil {
+			return nil, nil, fmt.Errorf("migrating %s -> %s: %w", m.From(), m.To(), err)
+		}
+
+		data = []byte(migrated.String())
+		version = m.To()
+		path = append(path, version)
+	}
+}
@@ -3,28 +3,20 @@ package generator
 import (
 	"fmt"
 	"kryptos/internal/config"
+	"kryptos/pkg/utils"
 
-	"github.com/goccy/go-yaml"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// K8sSecret represents a standard Kubernetes Secret
-type K8sSecret struct {
-	APIVersion string            `yaml:"apiVersion"`
-	Kind       string            `yaml:"kind"`
-	Metadata   Metadata          `yaml:"metadata"`
-	Type       string            `yaml:"type"`
-	StringData map[string]string `yaml:"stringData,omitempty"`
-	Data       map[string]string `yaml:"data,omitempty"`
-}
-
-type Metadata struct {
-	Name      string            `yaml:"name"`
-	Namespace string            `yaml:"namespace"`
-	Labels    map[string]string `yaml:"labels,omitempty"`
-}
+// GenerateRawSecret builds a Kubernetes Secret populated with the submitted
+// field data, ready to be handed to a Sealer without any intermediate YAML
+// round-trip.
+func GenerateRawSecret(cfg *config.AppConfig, secretCfg config.Secret, data map[string]string) (*corev1.Secret, error) {
+	if secretCfg.Type == string(corev1.SecretTypeTLS) {
+		return GenerateTLSSecret(cfg, secretCfg, data)
+	}
 
-// GenerateRawSecret creates a Kubernetes Secret struct populated with data
-func GenerateRawSecret(cfg *config.AppConfig, secretCfg config.Secret, data map[string]string) ([]byte, error) {
 	// Validate required keys
 	for _, field := range secretCfg.Fields {
 		// Checks if the key is required
@@ -38,15 +30,22 @@ func GenerateRawSecret(cfg *config.AppConfig, secretCfg config.Secret, data map[
 		}
 	}
 
-	secret := K8sSecret{
-		APIVersion: "v1",
-		Kind:       "Secret",
-		Metadata: Metadata{
+	secretType := corev1.SecretTypeOpaque
+	if secretCfg.Type != "" {
+		secretType = corev1.SecretType(secretCfg.Type)
+	}
+
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
 			Name:      secretCfg.Name,
 			Namespace: cfg.Namespace,
 			Labels:    secretCfg.Labels,
 		},
-		Type:       "Opaque", // Default, can be overridden if needed
+		Type:       secretType,
 		StringData: data,
 	}
 
@@ -58,5 +57,21 @@ func GenerateRawSecret(cfg *config.AppConfig, secretCfg config.Secret, data map[
 		secret.StringData[k] = v
 	}
 
-	return yaml.Marshal(secret)
+	return secret, nil
+}
+
+// ResolveFieldValue produces the non-interactive value for a field: its
+// declared Generator keyword takes precedence, falling back to Default.
+// Used by the CLI (create/rotate) where there's no form to type a value into.
+func ResolveFieldValue(field config.SecretField) (string, error) {
+	if field.Generator != "" {
+		val, ok, err := utils.GenerateByKeyword(field.Generator)
+		if err != nil {
+			return "", fmt.Errorf("generating field %q: %w", field.Name, err)
+		}
+		if ok {
+			return val, nil
+		}
+	}
+	return field.Default, nil
 }
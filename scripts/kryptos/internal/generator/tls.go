@@ -0,0 +1,129 @@
+package generator
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"kryptos/internal/config"
+	"math/big"
+	"os"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const defaultTLSDurationDays = 365
+
+// GenerateTLSSecret builds a kubernetes.io/tls Secret. If data supplies
+// "tls.crt"/"tls.key" paths, their contents are read as-is; otherwise a
+// self-signed certificate is generated in-process from secretCfg.TLS.
+func GenerateTLSSecret(cfg *config.AppConfig, secretCfg config.Secret, data map[string]string) (*corev1.Secret, error) {
+	certPEM, keyPEM, err := resolveTLSMaterial(secretCfg, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretCfg.Name,
+			Namespace: cfg.Namespace,
+			Labels:    secretCfg.Labels,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+		},
+	}, nil
+}
+
+func resolveTLSMaterial(secretCfg config.Secret, data map[string]string) (certPEM, keyPEM []byte, err error) {
+	certPath := data[corev1.TLSCertKey]
+	keyPath := data[corev1.TLSPrivateKeyKey]
+
+	if certPath != "" || keyPath != "" {
+		if certPath == "" || keyPath == "" {
+			return nil, nil, fmt.Errorf("both %s and %s must be supplied together, not just one", corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+		}
+
+		certPEM, err = os.ReadFile(certPath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading %s from %s: %w", corev1.TLSCertKey, certPath, err)
+		}
+		keyPEM, err = os.ReadFile(keyPath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading %s from %s: %w", corev1.TLSPrivateKeyKey, keyPath, err)
+		}
+		return certPEM, keyPEM, nil
+	}
+
+	return generateSelfSignedCert(secretCfg.TLS)
+}
+
+func generateSelfSignedCert(spec *config.TLSConfig) (certPEM, keyPEM []byte, err error) {
+	if spec == nil {
+		spec = &config.TLSConfig{}
+	}
+
+	commonName := spec.CommonName
+	if commonName == "" {
+		commonName = "localhost"
+	}
+
+	durationDays := spec.DurationDays
+	if durationDays <= 0 {
+		durationDays = defaultTLSDurationDays
+	}
+
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, fmt.Errorf("generating TLS key: %w", err)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, fmt.Errorf("generating certificate serial: %w", err)
+	}
+
+	// Go (and modern browsers) no longer fall back to the CommonName for
+	// hostname verification, so a serving cert needs commonName in its SAN
+	// list if the config didn't declare any explicitly.
+	sans := spec.SANs
+	if len(sans) == 0 {
+		sans = []string{commonName}
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName},
+		DNSNames:              sans,
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().AddDate(0, 0, durationDays),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &priv.PublicKey, priv)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating self-signed certificate: %w", err)
+	}
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshaling TLS key: %w", err)
+	}
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes})
+
+	return certPEM, keyPEM, nil
+}
@@ -2,17 +2,69 @@ package kubeseal
 
 import (
 	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"os"
 	"os/exec"
+
+	ssv1alpha1 "github.com/bitnami-labs/sealed-secrets/pkg/apis/sealed-secrets/v1alpha1"
+	"github.com/bitnami-labs/sealed-secrets/pkg/crypto"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	sigsyaml "sigs.k8s.io/yaml"
 )
 
-// Sealer handles interactions with the kubeseal binary
+// Scope controls which parts of a SealedSecret's identity (namespace, name)
+// the encryption is bound to, mirroring kubeseal's --scope flag.
+type Scope = ssv1alpha1.SealingScope
+
+// Sealer produces SealedSecrets. By default it seals in-process against a
+// cached copy of the sealed-secrets controller's public certificate. Setting
+// BinaryPath (via NewBinarySealer, or --use-binary) instead shells out to the
+// kubeseal CLI, preserved as a fallback while in-process sealing rolls out.
 type Sealer struct {
+	clientset           kubernetes.Interface
+	controllerNamespace string
+	controllerName      string
+	cert                *rsa.PublicKey
+
+	// BinaryPath, when set, routes SealSecret through the kubeseal binary.
 	BinaryPath string
+
+	privateKey *rsa.PrivateKey
+}
+
+// NewOfflineSealer creates a Sealer with neither a Kubernetes client nor the
+// kubeseal binary configured. It's only useful for local recovery decryption
+// via LoadPrivateKeyFromFile + Unseal.
+func NewOfflineSealer() *Sealer {
+	return &Sealer{}
+}
+
+// NewSealer creates a Sealer that seals secrets in-process, fetching the
+// controller's public certificate over the given REST config on first use.
+func NewSealer(restConfig *rest.Config, controllerNamespace, controllerName string) (*Sealer, error) {
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("building kubernetes client: %w", err)
+	}
+	return &Sealer{
+		clientset:           clientset,
+		controllerNamespace: controllerNamespace,
+		controllerName:      controllerName,
+	}, nil
 }
 
-// NewSealer creates a new Sealer instance
-func NewSealer() (*Sealer, error) {
+// NewBinarySealer creates a Sealer that shells out to the kubeseal binary.
+// This is the --use-binary fallback for environments where in-process
+// sealing isn't available yet.
+func NewBinarySealer() (*Sealer, error) {
 	path, err := exec.LookPath("kubeseal")
 	if err != nil {
 		return nil, fmt.Errorf("kubeseal binary not found in PATH: %w", err)
@@ -20,26 +72,203 @@ func NewSealer() (*Sealer, error) {
 	return &Sealer{BinaryPath: path}, nil
 }
 
-// CheckConnectivity verifies if kubeseal can reach the controller
-func (s *Sealer) CheckConnectivity() error {
-	// kubeseal --fetch-cert is a good way to check connectivity
-	cmd := exec.Command(s.BinaryPath, "--fetch-cert")
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to connect to sealed-secrets controller: %v\nOutput: %s", err, string(output))
+// FetchCert retrieves and caches the controller's public certificate by
+// proxying through the Kubernetes API server, the same route the kubeseal
+// CLI uses (no direct network access to the controller required).
+func (s *Sealer) FetchCert(ctx context.Context) error {
+	if s.clientset == nil {
+		return fmt.Errorf("kubeseal: no Kubernetes client configured to fetch controller cert")
+	}
+
+	data, err := s.clientset.CoreV1().RESTClient().
+		Get().
+		Namespace(s.controllerNamespace).
+		Resource("services").
+		Name(fmt.Sprintf("%s:%s", s.controllerName, "https")).
+		SubResource("proxy").
+		Suffix("v1/cert.pem").
+		DoRaw(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching sealed-secrets controller cert: %w", err)
+	}
+
+	cert, err := parseCertPEM(data)
+	if err != nil {
+		return err
+	}
+	s.cert = cert
+	return nil
+}
+
+// LoadCertFromFile loads and caches a controller public certificate from
+// disk, for air-gapped environments where the API server isn't reachable.
+func (s *Sealer) LoadCertFromFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading cert file %s: %w", path, err)
+	}
+
+	cert, err := parseCertPEM(data)
+	if err != nil {
+		return err
+	}
+	s.cert = cert
+	return nil
+}
+
+// LoadPrivateKeyFromFile loads and caches the controller's RSA private key
+// for local (recovery) decryption via Unseal. This is the same key material
+// kubeseal's --recovery-unseal flow uses.
+func (s *Sealer) LoadPrivateKeyFromFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading private key file %s: %w", path, err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return fmt.Errorf("no PEM data found in private key")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		s.privateKey = key
+		return nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("parsing private key: %w", err)
+	}
+	rsaKey, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return fmt.Errorf("private key is not RSA")
 	}
+	s.privateKey = rsaKey
 	return nil
 }
 
-// Seal generates a SealedSecret from a raw K8s Secret
-// input: The raw Secret YAML content
-// output: The SealedSecret YAML content
-func (s *Sealer) Seal(input []byte, namespace string, name string) ([]byte, error) {
+// Unseal decrypts a SealedSecret's fields using the private key loaded via
+// LoadPrivateKeyFromFile, assuming it was sealed with Scope strict (the
+// scope Kryptos' own callers pass to SealSecret).
+func (s *Sealer) Unseal(sealed *ssv1alpha1.SealedSecret) (*corev1.Secret, error) {
+	if s.privateKey == nil {
+		return nil, fmt.Errorf("kubeseal: no private key loaded; call LoadPrivateKeyFromFile first")
+	}
+
+	label := ssv1alpha1.EncryptionLabel(sealed.Namespace, sealed.Name, ssv1alpha1.StrictScope)
+
+	data := make(map[string][]byte, len(sealed.Spec.EncryptedData))
+	for k, v := range sealed.Spec.EncryptedData {
+		dec, err := crypto.HybridDecrypt(rand.Reader, s.privateKey, v, label)
+		if err != nil {
+			return nil, fmt.Errorf("decrypting field %q: %w", k, err)
+		}
+		data[k] = dec
+	}
+
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      sealed.Name,
+			Namespace: sealed.Namespace,
+			Labels:    sealed.Spec.Template.ObjectMeta.Labels,
+		},
+		Type: sealed.Spec.Template.Type,
+		Data: data,
+	}, nil
+}
+
+func parseCertPEM(data []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in certificate")
+	}
+
+	parsed, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing certificate: %w", err)
+	}
+
+	pub, ok := parsed.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("certificate public key is not RSA")
+	}
+	return pub, nil
+}
+
+// SealSecret encrypts a raw Secret's data into a SealedSecret, bound to the
+// secret's namespace and/or name as scope selects. It fetches and caches the controller cert on
+// first use if one hasn't already been loaded via FetchCert/LoadCertFromFile.
+func (s *Sealer) SealSecret(ctx context.Context, secret *corev1.Secret, scope Scope) (*ssv1alpha1.SealedSecret, error) {
+	if s.BinaryPath != "" {
+		return s.sealWithBinary(secret, scope)
+	}
+
+	if s.cert == nil {
+		if err := s.FetchCert(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	label := ssv1alpha1.EncryptionLabel(secret.Namespace, secret.Name, scope)
+
+	encryptedData := make(map[string][]byte, len(secret.Data)+len(secret.StringData))
+	for k, v := range secret.Data {
+		enc, err := crypto.HybridEncrypt(rand.Reader, s.cert, v, label)
+		if err != nil {
+			return nil, fmt.Errorf("encrypting field %q: %w", k, err)
+		}
+		encryptedData[k] = enc
+	}
+	for k, v := range secret.StringData {
+		enc, err := crypto.HybridEncrypt(rand.Reader, s.cert, []byte(v), label)
+		if err != nil {
+			return nil, fmt.Errorf("encrypting field %q: %w", k, err)
+		}
+		encryptedData[k] = enc
+	}
+
+	return &ssv1alpha1.SealedSecret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: ssv1alpha1.SchemeGroupVersion.String(),
+			Kind:       "SealedSecret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secret.Name,
+			Namespace: secret.Namespace,
+		},
+		Spec: ssv1alpha1.SealedSecretSpec{
+			Template: ssv1alpha1.SecretTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      secret.Name,
+					Namespace: secret.Namespace,
+					Labels:    secret.Labels,
+				},
+				Type: secret.Type,
+			},
+			EncryptedData: encryptedData,
+		},
+	}, nil
+}
+
+// sealWithBinary shells out to the kubeseal binary, preserving the old
+// exec-based path behind --use-binary.
+func (s *Sealer) sealWithBinary(secret *corev1.Secret, scope Scope) (*ssv1alpha1.SealedSecret, error) {
+	secret.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"}
+	input, err := sigsyaml.Marshal(secret)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling secret: %w", err)
+	}
+
 	args := []string{
 		"--format", "yaml",
-		"--controller-namespace", "kube-system", // Default, make configurable?
-		// explicitly set name and namespace to ensure they match
-		"--name", name,
-		"--namespace", namespace,
+		"--controller-namespace", "kube-system",
+		"--name", secret.Name,
+		"--namespace", secret.Namespace,
+		"--scope", scope.String(),
 	}
 
 	cmd := exec.Command(s.BinaryPath, args...)
@@ -53,5 +282,38 @@ func (s *Sealer) Seal(input []byte, namespace string, name string) ([]byte, erro
 		return nil, fmt.Errorf("kubeseal failed: %v\nStderr: %s", err, stderr.String())
 	}
 
-	return stdout.Bytes(), nil
+	var sealed ssv1alpha1.SealedSecret
+	if err := sigsyaml.Unmarshal(stdout.Bytes(), &sealed); err != nil {
+		return nil, fmt.Errorf("parsing kubeseal output: %w", err)
+	}
+	return &sealed, nil
+}
+
+// CheckConnectivity verifies the Sealer can reach the sealed-secrets
+// controller, either by fetching its cert (in-process) or by invoking
+// kubeseal --fetch-cert (binary fallback).
+func (s *Sealer) CheckConnectivity() error {
+	if s.BinaryPath != "" {
+		cmd := exec.Command(s.BinaryPath, "--fetch-cert")
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to connect to sealed-secrets controller: %v\nOutput: %s", err, string(output))
+		}
+		return nil
+	}
+	return s.FetchCert(context.Background())
+}
+
+// MarshalSealedSecret renders a SealedSecret as YAML for writing to disk.
+func MarshalSealedSecret(sealed *ssv1alpha1.SealedSecret) ([]byte, error) {
+	return sigsyaml.Marshal(sealed)
+}
+
+// UnmarshalSealedSecret parses a SealedSecret envelope previously written by
+// MarshalSealedSecret, e.g. to inspect or list it without decrypting.
+func UnmarshalSealedSecret(data []byte) (*ssv1alpha1.SealedSecret, error) {
+	var sealed ssv1alpha1.SealedSecret
+	if err := sigsyaml.Unmarshal(data, &sealed); err != nil {
+		return nil, fmt.Errorf("parsing sealed secret: %w", err)
+	}
+	return &sealed, nil
 }
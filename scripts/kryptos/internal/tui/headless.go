@@ -0,0 +1,215 @@
+package tui
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"kryptos/internal/certinfo"
+	"kryptos/internal/config"
+	"kryptos/internal/generator"
+	"kryptos/internal/kubeseal"
+	"kryptos/pkg/utils"
+	"os"
+	"path/filepath"
+	"strings"
+
+	ssv1alpha1 "github.com/bitnami-labs/sealed-secrets/pkg/apis/sealed-secrets/v1alpha1"
+	"github.com/goccy/go-yaml"
+	corev1 "k8s.io/api/core/v1"
+	"golang.org/x/term"
+)
+
+// IsInteractive reports whether stdin looks like a real terminal. Kryptos
+// falls back to headless mode when it doesn't (piped input, redirected
+// file, CI runner).
+func IsInteractive() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// HeadlessOptions configures RunHeadless's output.
+type HeadlessOptions struct {
+	Stdout   io.Writer
+	ToStdout bool // emit sealed YAML to Stdout instead of the resolved secrets dir
+}
+
+// RunHeadless reads "app/secret/field=value" lines (or an equivalent YAML
+// document of the same shape) from stdin, resolves each field against the
+// loaded AppConfig/Secret/SecretField, applies the secure/strong/apikey/
+// passphrase generator keywords the interactive form recognizes, validates
+// Required fields, and seals the result.
+func RunHeadless(ctx context.Context, stdin io.Reader, configs []*config.AppConfig, sealer *kubeseal.Sealer, opts HeadlessOptions) error {
+	entries, err := parseHeadlessInput(stdin)
+	if err != nil {
+		return err
+	}
+
+	byApp := make(map[string]*config.AppConfig, len(configs))
+	for _, cfg := range configs {
+		byApp[cfg.AppName] = cfg
+	}
+
+	for appName, secrets := range entries {
+		appCfg, ok := byApp[appName]
+		if !ok {
+			return fmt.Errorf("headless input: unknown app %q", appName)
+		}
+
+		for secretName, fields := range secrets {
+			secretCfg, err := findSecretConfig(appCfg, secretName)
+			if err != nil {
+				return err
+			}
+
+			data, err := resolveHeadlessData(*secretCfg, fields)
+			if err != nil {
+				return fmt.Errorf("%s/%s: %w", appName, secretName, err)
+			}
+
+			if err := sealAndEmit(ctx, appCfg, *secretCfg, data, sealer, opts); err != nil {
+				return fmt.Errorf("%s/%s: %w", appName, secretName, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// resolveHeadlessData merges stdin-supplied values (running them through the
+// same generator keywords the TUI form does) with config-declared
+// generators/defaults for any field stdin didn't mention.
+func resolveHeadlessData(secretCfg config.Secret, fields map[string]string) (map[string]string, error) {
+	data := make(map[string]string, len(fields))
+	for name, val := range fields {
+		generated, matched, err := utils.GenerateByKeyword(val)
+		if err != nil {
+			return nil, fmt.Errorf("generating field %q: %w", name, err)
+		}
+		if matched {
+			val = generated
+		}
+		data[name] = val
+	}
+
+	for _, field := range secretCfg.Fields {
+		if _, supplied := data[field.Name]; supplied {
+			continue
+		}
+		val, err := generator.ResolveFieldValue(field)
+		if err != nil {
+			return nil, err
+		}
+		if val != "" {
+			data[field.Name] = val
+		}
+	}
+
+	return data, nil
+}
+
+func sealAndEmit(ctx context.Context, appCfg *config.AppConfig, secretCfg config.Secret, data map[string]string, sealer *kubeseal.Sealer, opts HeadlessOptions) error {
+	rawSecret, err := generator.GenerateRawSecret(appCfg, secretCfg, data)
+	if err != nil {
+		return err
+	}
+
+	sealedSecret, err := sealer.SealSecret(ctx, rawSecret, ssv1alpha1.StrictScope)
+	if err != nil {
+		return err
+	}
+
+	sealedYAML, err := kubeseal.MarshalSealedSecret(sealedSecret)
+	if err != nil {
+		return err
+	}
+
+	if rawSecret.Type == corev1.SecretTypeTLS {
+		if info, parseErr := certinfo.Parse(rawSecret.Data[corev1.TLSCertKey]); parseErr == nil {
+			if dir, dirErr := config.FindSecretsDir(appCfg.AppName); dirErr == nil {
+				_ = certinfo.WriteCache(dir, secretCfg.Name, info)
+			}
+		}
+	}
+
+	if opts.ToStdout {
+		fmt.Fprintf(opts.Stdout, "---\n%s", sealedYAML)
+		return nil
+	}
+
+	secretsDir, err := config.FindSecretsDir(appCfg.AppName)
+	if err != nil {
+		return err
+	}
+	outputFile := filepath.Join(secretsDir, secretCfg.Name+".yaml")
+	if err := os.WriteFile(outputFile, sealedYAML, 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", outputFile, err)
+	}
+	fmt.Fprintf(opts.Stdout, "Successfully generated %s\n", outputFile)
+	return nil
+}
+
+func findSecretConfig(appCfg *config.AppConfig, name string) (*config.Secret, error) {
+	for i := range appCfg.Secrets {
+		if appCfg.Secrets[i].Name == name {
+			return &appCfg.Secrets[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no secret %q configured for app %q", name, appCfg.AppName)
+}
+
+// parseHeadlessInput accepts either "app/secret/field=value" lines or a
+// YAML document of app -> secret -> field -> value.
+func parseHeadlessInput(r io.Reader) (map[string]map[string]map[string]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading stdin: %w", err)
+	}
+
+	if entries, ok := parseHeadlessLines(data); ok {
+		return entries, nil
+	}
+
+	var doc map[string]map[string]map[string]string
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("expected app/secret/field=value lines or a YAML document, got: %w", err)
+	}
+	return doc, nil
+}
+
+func parseHeadlessLines(data []byte) (map[string]map[string]map[string]string, bool) {
+	entries := make(map[string]map[string]map[string]string)
+	found := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		path, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, false
+		}
+		parts := strings.SplitN(path, "/", 3)
+		if len(parts) != 3 {
+			return nil, false
+		}
+
+		app, secret, field := parts[0], parts[1], parts[2]
+		if entries[app] == nil {
+			entries[app] = make(map[string]map[string]string)
+		}
+		if entries[app][secret] == nil {
+			entries[app][secret] = make(map[string]string)
+		}
+		entries[app][secret][field] = value
+		found = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, false
+	}
+
+	return entries, found
+}
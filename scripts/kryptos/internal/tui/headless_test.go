@@ -0,0 +1,95 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseHeadlessLines(t *testing.T) {
+	input := []byte(`
+# a comment line, should be skipped
+
+demo/api-creds/token=abc123
+demo/api-creds/secret=s3cr3t
+other/db/password=generate:secure
+`)
+
+	entries, ok := parseHeadlessLines(input)
+	if !ok {
+		t.Fatal("expected parseHeadlessLines to recognize the line format")
+	}
+
+	want := map[string]map[string]map[string]string{
+		"demo": {
+			"api-creds": {
+				"token":  "abc123",
+				"secret": "s3cr3t",
+			},
+		},
+		"other": {
+			"db": {
+				"password": "generate:secure",
+			},
+		},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("entries = %#v, want %#v", entries, want)
+	}
+}
+
+func TestParseHeadlessLines_RejectsNonLineFormat(t *testing.T) {
+	// A YAML document isn't "app/secret/field=value" lines: the first
+	// non-blank, non-comment line has no '=' or no exactly three '/' parts,
+	// so parseHeadlessLines should bail out rather than half-parse it.
+	input := []byte(`
+demo:
+  api-creds:
+    token: abc123
+`)
+
+	if _, ok := parseHeadlessLines(input); ok {
+		t.Fatal("expected parseHeadlessLines to reject a YAML document")
+	}
+}
+
+func TestParseHeadlessLines_EmptyInput(t *testing.T) {
+	entries, ok := parseHeadlessLines([]byte("\n\n# just a comment\n"))
+	if ok {
+		t.Fatalf("expected ok=false for input with no data lines, got entries=%#v", entries)
+	}
+}
+
+func TestParseHeadlessInput_FallsBackToYAML(t *testing.T) {
+	input := strings.NewReader(`
+demo:
+  api-creds:
+    token: abc123
+    secret: s3cr3t
+`)
+
+	entries, err := parseHeadlessInput(input)
+	if err != nil {
+		t.Fatalf("parseHeadlessInput: %v", err)
+	}
+
+	want := map[string]map[string]map[string]string{
+		"demo": {
+			"api-creds": {
+				"token":  "abc123",
+				"secret": "s3cr3t",
+			},
+		},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("entries = %#v, want %#v", entries, want)
+	}
+}
+
+func TestParseHeadlessInput_MalformedLine(t *testing.T) {
+	input := strings.NewReader("demo/api-creds/token\n")
+
+	if _, err := parseHeadlessInput(input); err == nil {
+		t.Fatal("expected an error for a line with no '='")
+	}
+}
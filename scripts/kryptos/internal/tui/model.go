@@ -1,7 +1,9 @@
 package tui
 
 import (
+	"context"
 	"fmt"
+	"kryptos/internal/certinfo"
 	"kryptos/internal/config"
 	"kryptos/internal/generator"
 	"kryptos/internal/kubeseal"
@@ -10,10 +12,12 @@ import (
 	"path/filepath"
 	"strings"
 
+	ssv1alpha1 "github.com/bitnami-labs/sealed-secrets/pkg/apis/sealed-secrets/v1alpha1"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	corev1 "k8s.io/api/core/v1"
 )
 
 // MainModel is the main Bubble Tea model
@@ -135,15 +139,9 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						val := input.Value()
 
 						// Auto-generation logic matching original script
-						var err error
-						if val == "secure" {
-							val, err = utils.GenerateSecurePassword(32, false)
-						} else if val == "strong" {
-							val, err = utils.GenerateSecurePassword(32, true)
-						} else if val == "apikey" {
-							val, err = utils.GenerateAPIKey(64)
-						} else if val == "passphrase" {
-							val = utils.GeneratePassphrase(4, "-")
+						generated, matched, err := utils.GenerateByKeyword(val)
+						if matched {
+							val = generated
 						}
 
 						if err != nil {
@@ -161,8 +159,13 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						return m, tea.Quit
 					}
 
-					// Seal it
-					sealedSecret, err := m.sealer.Seal(rawSecret, m.selected.Namespace, m.currentSecret.Name)
+					// Seal it (in-process, or via kubeseal with --use-binary)
+					sealedSecret, err := m.sealer.SealSecret(context.Background(), rawSecret, ssv1alpha1.StrictScope)
+					if err != nil {
+						return m, tea.Quit
+					}
+
+					sealedYAML, err := kubeseal.MarshalSealedSecret(sealedSecret)
 					if err != nil {
 						return m, tea.Quit
 					}
@@ -178,10 +181,16 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					outputFile := filepath.Join(secretsDir, m.currentSecret.Name+".yaml")
 					// If filename is customizable in config, use it (TODO)
 
-					if err := os.WriteFile(outputFile, sealedSecret, 0644); err != nil {
+					if err := os.WriteFile(outputFile, sealedYAML, 0644); err != nil {
 						return m, tea.Quit
 					}
 
+					if rawSecret.Type == corev1.SecretTypeTLS {
+						if info, err := certinfo.Parse(rawSecret.Data[corev1.TLSCertKey]); err == nil {
+							_ = certinfo.WriteCache(secretsDir, m.currentSecret.Name, info)
+						}
+					}
+
 					fmt.Printf("\nSuccessfully generated %s\n", outputFile)
 					return m, tea.Quit
 				}
@@ -311,7 +320,15 @@ func (m MainModel) viewDetails() string {
 	s += "\n\nAvailable Secrets:\n"
 
 	for i, sec := range m.selected.Secrets {
-		s += fmt.Sprintf("  %d. %s (%s)\n", i+1, sec.DisplayName, sec.Type)
+		warning := ""
+		if sec.Type == string(corev1.SecretTypeTLS) {
+			if secretsDir, err := config.FindSecretsDir(m.selected.AppName); err == nil {
+				if info, err := certinfo.ReadCache(secretsDir, sec.Name); err == nil && info.DaysUntilExpiry() <= 30 {
+					warning = " ⚠ expires in " + fmt.Sprintf("%d", info.DaysUntilExpiry()) + "d"
+				}
+			}
+		}
+		s += fmt.Sprintf("  %d. %s (%s)%s\n", i+1, sec.DisplayName, sec.Type, warning)
 	}
 
 	s += "\nType the number of the secret to generate it.\n"
@@ -97,6 +97,25 @@ func GeneratePassphrase(wordCount int, separator string) string {
 	return strings.Join(selected, separator)
 }
 
+// GenerateByKeyword produces a value for one of Kryptos' recognized
+// generator keywords (secure, strong, apikey, passphrase). ok is false, and
+// value is empty, if keyword isn't one of these.
+func GenerateByKeyword(keyword string) (value string, ok bool, err error) {
+	switch keyword {
+	case "secure":
+		value, err = GenerateSecurePassword(defaultPasswordLength, false)
+	case "strong":
+		value, err = GenerateSecurePassword(defaultPasswordLength, true)
+	case "apikey":
+		value, err = GenerateAPIKey(apiKeyLength)
+	case "passphrase":
+		value = GeneratePassphrase(4, "-")
+	default:
+		return "", false, nil
+	}
+	return value, true, err
+}
+
 // Helper: randInt returns a secure random integer [0, max)
 func randInt(max int) (int, error) {
 	n, err := rand.Int(rand.Reader, big.NewInt(int64(max)))